@@ -1,7 +1,9 @@
 package dht
 
 import (
+	"context"
 	"fmt"
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/host/v3"
 	"time"
 )
@@ -12,10 +14,26 @@ func HostInit() error {
 	return err
 }
 
-// Read reads the sensor once, returing humidity and temperature, or an error.
+// Read reads the sensor once, returning a Measurement or an error.
+// If Smoothing is set, Read transparently repeats the transaction across the
+// configured window and returns a filtered value instead, see SmoothingConfig.
 // Note that Read will sleep for at least 2 seconds between last call.
 // Each reads error adds a half second to sleep time to max of 30 seconds.
-func (dht *DHT) Read() (humidity float64, temperature float64, err error) {
+func (dht *DHT) Read(ctx context.Context) (Measurement, error) {
+	if dht.Smoothing == nil || dht.Smoothing.WindowSize <= 1 {
+		return dht.readOnce(ctx)
+	}
+
+	samples, err := dht.collectSamples(ctx, dht.Smoothing.WindowSize)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	return smoothSamples(samples, dht.Smoothing), nil
+}
+
+// readOnce performs a single, unsmoothed read transaction.
+func (dht *DHT) readOnce(ctx context.Context) (Measurement, error) {
 	// set sleepTime
 	var sleepTime time.Duration
 	if dht.numErrors < 57 {
@@ -27,19 +45,115 @@ func (dht *DHT) Read() (humidity float64, temperature float64, err error) {
 	sleepTime -= time.Since(dht.lastRead)
 
 	// sleep between 2 and 30 seconds
-	time.Sleep(sleepTime)
+	select {
+	case <-time.After(sleepTime):
+	case <-ctx.Done():
+		return Measurement{}, ctx.Err()
+	}
 
 	// read bits from sensor
-	var bits []int
-	bits, err = dht.readBits()
+	bits, err := dht.readBits()
 	if err != nil {
-		return
+		dht.logger.Warnf("read bits error: %v", err)
+		return dht.handleReadError(ctx, err)
 	}
 
 	// covert bits to humidity and temperature
-	humidity, temperature, err = dht.bitsToValues(bits)
+	humidity, temperature, err := dht.bitsToValues(bits)
+	if err != nil {
+		dht.logger.Warnf("bad data: %v", err)
+		return dht.handleReadError(ctx, err)
+	}
 
-	return
+	dht.numErrors = 0
+	dht.logger.Infof("read ok: humidity=%.1f temperature=%.1f", humidity, temperature)
+
+	return Measurement{Humidity: humidity, Temperature: temperature, Unit: dht.temperatureUnit, Time: time.Now()}, nil
+}
+
+// collectSamples takes n unsmoothed readings in a row, each naturally spaced
+// by readOnce's 2 second minimum interval, stopping at the first error.
+func (dht *DHT) collectSamples(ctx context.Context, n int) ([]Measurement, error) {
+	samples := make([]Measurement, n)
+	for i := 0; i < n; i++ {
+		measurement, err := dht.readOnce(ctx)
+		if err != nil {
+			return nil, err
+		}
+		samples[i] = measurement
+	}
+	return samples, nil
+}
+
+// ReadSmoothed takes n samples, each spaced by the mandatory 2 second
+// interval between reads, drops the minimum and maximum of each field, and
+// averages what remains. Unlike Smoothing this needs no prior configuration,
+// making it a quick way to get a steadier reading for logging/graphing.
+// n must be at least 3, otherwise there would be nothing left to average.
+func (dht *DHT) ReadSmoothed(ctx context.Context, n int) (Measurement, error) {
+	if n < 3 {
+		return Measurement{}, fmt.Errorf("n must be at least 3, got %v", n)
+	}
+
+	samples, err := dht.collectSamples(ctx, n)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	humidities := make([]float64, len(samples))
+	temperatures := make([]float64, len(samples))
+	for i, m := range samples {
+		humidities[i] = m.Humidity
+		temperatures[i] = m.Temperature
+	}
+
+	last := samples[len(samples)-1]
+	return Measurement{
+		Humidity:    dropMinMaxMean(humidities),
+		Temperature: dropMinMaxMean(temperatures),
+		Unit:        last.Unit,
+		Time:        last.Time,
+	}, nil
+}
+
+// handleReadError counts readErr towards MaxConsecutiveErrors and, once it is
+// exceeded, runs RecoveryFunc so a long-running daemon can survive transient
+// wiring/EMI issues without a process restart. ctx is passed through to
+// RecoveryFunc so cancelling it can interrupt a stuck recovery.
+func (dht *DHT) handleReadError(ctx context.Context, readErr error) (Measurement, error) {
+	dht.numErrors++
+
+	if dht.MaxConsecutiveErrors <= 0 || dht.numErrors <= dht.MaxConsecutiveErrors || dht.RecoveryFunc == nil {
+		return Measurement{}, readErr
+	}
+
+	dht.logger.Warnf("attempting bus recovery after %v consecutive errors", dht.numErrors)
+	if err := dht.RecoveryFunc(ctx, dht); err != nil {
+		dht.logger.Errorf("bus recovery failed: %v", err)
+		return Measurement{}, fmt.Errorf("%w: %v", ErrRecoveryFailed, err)
+	}
+
+	dht.numErrors = 0
+	dht.logger.Infof("bus recovery succeeded")
+	return Measurement{}, ErrRecovered
+}
+
+// SetLogger sets the Logger that retries, checksum failures, timing
+// anomalies, and successful reads are reported to. The default is NopLogger,
+// which discards everything. Passing nil resets it back to NopLogger.
+func (dht *DHT) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	dht.logger = logger
+}
+
+// Close returns the pin to a high idle state. DHT has no other resources to release.
+func (dht *DHT) Close() error {
+	if dht.pin == nil {
+		return nil
+	}
+	return dht.pin.Out(gpio.High)
 }
 
 // bitsToValues will convert the bits into humidity and temperature values
@@ -145,51 +259,25 @@ func (dht *DHT) bitsToValues(bits []int) (humidity float64, temperature float64,
 
 // ReadRetry will call Read until there is no errors or the maxRetries is hit.
 // Suggest maxRetries to be set around 11.
-func (dht *DHT) ReadRetry(maxRetries int) (humidity float64, temperature float64, err error) {
+func (dht *DHT) ReadRetry(ctx context.Context, maxRetries int) (measurement Measurement, err error) {
 	for i := 0; i < maxRetries; i++ {
-		humidity, temperature, err = dht.Read()
+		measurement, err = dht.Read(ctx)
 		if err == nil {
 			return
 		}
+		dht.logger.Debugf("retry %v/%v after error: %v", i+1, maxRetries, err)
 	}
+	dht.logger.Errorf("read failed after %v retries: %v", maxRetries, err)
 	return
 }
 
 // ReadBackground it meant to be run in the background, run as a Goroutine.
 // sleepDuration is how long it will try to sleep between reads.
-// If there is ongoing read errors there will be no notice except that the values will not be updated.
+// events is optional; pass nil to skip it. If non-nil, a ReadEvent is sent
+// after every read attempt, see ReadEvent.
 // Will continue to read sensor until stop is closed.
 // After it has been stopped, the stopped chan will be closed.
-// Will panic if humidity, temperature, or stop are nil.
-func (dht *DHT) ReadBackground(humidity *float64, temperature *float64, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{}) {
-	var humidityTemp float64
-	var temperatureTemp float64
-	var err error
-	var startTime time.Time
-
-Loop:
-	for {
-		startTime = time.Now()
-		humidityTemp, temperatureTemp, err = dht.Read()
-		if err == nil {
-			// no read error, save result
-			*humidity = humidityTemp
-			*temperature = temperatureTemp
-			// wait for sleepDuration or stop
-			select {
-			case <-time.After(sleepDuration - time.Since(startTime)):
-			case <-stop:
-				break Loop
-			}
-		} else {
-			// read error, just check for stop
-			select {
-			case <-stop:
-				break Loop
-			default:
-			}
-		}
-	}
-
-	close(stopped)
+// Will panic if measurement or stopped are nil.
+func (dht *DHT) ReadBackground(measurement *Measurement, events chan<- ReadEvent, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{}) {
+	readBackgroundLoop(dht.Read, measurement, events, sleepDuration, stop, stopped)
 }
@@ -0,0 +1,168 @@
+//go:build cgo && linux
+// +build cgo,linux
+
+package dht
+
+/*
+#include <stdint.h>
+#include <time.h>
+#include <sched.h>
+#include <fcntl.h>
+#include <unistd.h>
+#include <sys/mman.h>
+
+#define DHT_GPIO_BLOCK_SIZE 4096
+#define DHT_GPLEV0_WORD     13
+
+static volatile uint32_t *dht_gpio_map = NULL;
+
+static int dht_map_gpio() {
+	if (dht_gpio_map != NULL) {
+		return 0;
+	}
+	int fd = open("/dev/gpiomem", O_RDWR | O_SYNC);
+	if (fd < 0) {
+		return -1;
+	}
+	// unlike /dev/mem, /dev/gpiomem is a single dedicated page starting at the
+	// GPIO registers already, so it is mapped at offset 0, see
+	// periph.io/x/host/v3/pmem.mapGPIOLinux.
+	void *mapped = mmap(NULL, DHT_GPIO_BLOCK_SIZE, PROT_READ | PROT_WRITE, MAP_SHARED, fd, 0);
+	close(fd);
+	if (mapped == MAP_FAILED) {
+		return -1;
+	}
+	dht_gpio_map = (volatile uint32_t *)mapped;
+	return 0;
+}
+
+static inline int dht_gpio_level(int pin) {
+	return (dht_gpio_map[DHT_GPLEV0_WORD] >> pin) & 1;
+}
+
+// dht_capture busy-polls pin, recording how long each level lasted in
+// durationsUs (microseconds) and which level it was in levelsOut, until
+// maxSamples levels have been captured. A level that lasts longer than
+// timeoutUs is recorded with that duration and capture moves on to the next
+// sample. Returns the number of samples captured, or -1 if /dev/gpiomem could
+// not be mapped.
+int dht_capture(int pin, int32_t *durationsUs, int32_t *levelsOut, int maxSamples, int64_t timeoutUs) {
+	if (dht_map_gpio() != 0) {
+		return -1;
+	}
+
+	struct timespec edgeStart, now;
+	int level = dht_gpio_level(pin);
+	int levelPrevious = level;
+
+	int count = 0;
+	clock_gettime(CLOCK_MONOTONIC, &edgeStart);
+	while (count < maxSamples) {
+		int64_t elapsedUs;
+		do {
+			level = dht_gpio_level(pin);
+			clock_gettime(CLOCK_MONOTONIC, &now);
+			elapsedUs = (now.tv_sec - edgeStart.tv_sec) * 1000000LL + (now.tv_nsec - edgeStart.tv_nsec) / 1000LL;
+		} while (level == levelPrevious && elapsedUs < timeoutUs);
+
+		durationsUs[count] = (int32_t)elapsedUs;
+		levelsOut[count] = levelPrevious;
+		levelPrevious = level;
+		count++;
+		edgeStart = now;
+	}
+
+	return count;
+}
+
+// dht_raise_priority switches the calling thread to SCHED_FIFO so the capture
+// loop above is not preempted mid pulse, saving the previous policy/priority
+// so dht_restore_priority can put the thread back how it found it.
+int dht_raise_priority(int *oldPolicy, int *oldPriority) {
+	struct sched_param param;
+
+	*oldPolicy = sched_getscheduler(0);
+	if (sched_getparam(0, &param) != 0) {
+		return -1;
+	}
+	*oldPriority = param.sched_priority;
+
+	param.sched_priority = sched_get_priority_max(SCHED_FIFO);
+	return sched_setscheduler(0, SCHED_FIFO, &param);
+}
+
+int dht_restore_priority(int oldPolicy, int oldPriority) {
+	struct sched_param param;
+	param.sched_priority = oldPriority;
+	return sched_setscheduler(0, oldPolicy, &param);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"periph.io/x/conn/v3/gpio"
+	"time"
+)
+
+// readBitsCGPIOMem captures pulses by mmaping /dev/gpiomem and polling the
+// GPLEV register directly, timing edges with clock_gettime(CLOCK_MONOTONIC),
+// instead of the periph.io busy loop in readBitsPeriph. This avoids Go's
+// scheduler and time.Now overhead eating into the 26-70us pulse budget on
+// slower ARM boards such as the Pi Zero/1. See NewDHTWithBackend.
+func (dht *DHT) readBitsCGPIOMem() ([]int, error) {
+	pinNumber := dht.pin.Number()
+
+	// set lastRead so do not read more than once every 2 seconds
+	dht.lastRead = time.Now()
+
+	// raising to SCHED_FIFO is best effort: it needs CAP_SYS_NICE, which the
+	// unprivileged users /dev/gpiomem is meant to support typically won't
+	// have, so don't fail the read over it, just capture without it.
+	var oldPolicy, oldPriority C.int
+	if C.dht_raise_priority(&oldPolicy, &oldPriority) == 0 {
+		defer C.dht_restore_priority(oldPolicy, oldPriority)
+	} else {
+		dht.logger.Warnf("sched_setscheduler raise error, capturing without realtime priority")
+	}
+
+	// send start low
+	err := dht.pin.Out(gpio.Low)
+	if err != nil {
+		dht.pin.Out(gpio.High)
+		return nil, fmt.Errorf("pin out low error: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	// send start high
+	err = dht.pin.In(gpio.PullUp, gpio.NoEdge)
+	if err != nil {
+		dht.pin.Out(gpio.High)
+		return nil, fmt.Errorf("pin in error: %v", err)
+	}
+
+	const maxSamples = 84
+	var durationsUs [maxSamples]C.int32_t
+	var levelsOut [maxSamples]C.int32_t
+
+	count := C.dht_capture(C.int(pinNumber), &durationsUs[0], &levelsOut[0], maxSamples, C.int64_t(time.Millisecond/time.Microsecond))
+	if count < 0 {
+		dht.pin.Out(gpio.High)
+		return nil, fmt.Errorf("gpiomem map error, is /dev/gpiomem readable?")
+	}
+
+	// set pin to high so ready for next time
+	err = dht.pin.Out(gpio.High)
+	if err != nil {
+		return nil, fmt.Errorf("pin out high error: %v", err)
+	}
+
+	levels := make([]gpio.Level, count)
+	durations := make([]time.Duration, count)
+	for i := 0; i < int(count); i++ {
+		levels[i] = gpio.Level(levelsOut[i] != 0)
+		durations[i] = time.Duration(durationsUs[i]) * time.Microsecond
+	}
+
+	return decodePulses(levels, durations)
+}
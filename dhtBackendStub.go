@@ -0,0 +1,13 @@
+//go:build !windows && !(cgo && linux)
+// +build !windows
+// +build !cgo !linux
+
+package dht
+
+import "fmt"
+
+// readBitsCGPIOMem is not available on this platform/build: BackendCGPIOMem
+// needs cgo and Linux to mmap /dev/gpiomem, see NewDHTWithBackend.
+func (dht *DHT) readBitsCGPIOMem() ([]int, error) {
+	return nil, fmt.Errorf("BackendCGPIOMem requires cgo and linux, rebuild with CGO_ENABLED=1 on linux")
+}
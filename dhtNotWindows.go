@@ -4,6 +4,7 @@
 package dht
 
 import (
+	"context"
 	"fmt"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
@@ -15,7 +16,22 @@ import (
 // NewDHT to create a new DHT struct.
 // sensorType is dht11 for DHT11, anything else for AM2302 / DHT22.
 func NewDHT(pinName string, temperatureUnit TemperatureUnit, sensorType string) (*DHT, error) {
-	dht := &DHT{temperatureUnit: temperatureUnit}
+	return NewDHTWithBackend(pinName, temperatureUnit, sensorType, BackendPeriph)
+}
+
+// NewDHTWithBackend to create a new DHT struct, picking which backend is used
+// to capture the sensor's pulse train. Most callers should use NewDHT, which
+// defaults to BackendPeriph; BackendCGPIOMem is only useful on boards where
+// BackendPeriph misses edges, see Backend.
+// sensorType is dht11 for DHT11, anything else for AM2302 / DHT22.
+func NewDHTWithBackend(pinName string, temperatureUnit TemperatureUnit, sensorType string, backend Backend) (*DHT, error) {
+	dht := &DHT{
+		temperatureUnit:      temperatureUnit,
+		backend:              backend,
+		logger:               NopLogger{},
+		MaxConsecutiveErrors: 60,
+		RecoveryFunc:         DefaultRecoveryFunc,
+	}
 
 	// set sensorType
 	sensorType = strings.ToLower(sensorType)
@@ -41,8 +57,60 @@ func NewDHT(pinName string, temperatureUnit TemperatureUnit, sensorType string)
 	return dht, nil
 }
 
-// readBits will get the bits for humidity and temperature
+// DefaultRecoveryFunc drives the pin low for RecoveryPowerDownDuration (20
+// seconds if unset), releases it, then brings it back high, mirroring
+// fermentord's 1-wire bus reset. It is the default DHT.RecoveryFunc; assign a
+// different func(context.Context, *DHT) error to customize or disable
+// recovery. ctx is the one passed to Read, so cancelling it aborts the
+// power-down wait instead of blocking for the full duration.
+func DefaultRecoveryFunc(ctx context.Context, dht *DHT) error {
+	duration := dht.RecoveryPowerDownDuration
+	if duration <= 0 {
+		duration = 20 * time.Second
+	}
+
+	if err := dht.pin.Out(gpio.Low); err != nil {
+		return fmt.Errorf("pin out low error: %v", err)
+	}
+
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := dht.pin.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		return fmt.Errorf("pin release error: %v", err)
+	}
+
+	select {
+	case <-time.After(time.Second):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := dht.pin.Out(gpio.High); err != nil {
+		return fmt.Errorf("pin out high error: %v", err)
+	}
+
+	// restart the 2 second wait so the pin can warm up again before the next read
+	dht.lastRead = time.Now()
+
+	return nil
+}
+
+// readBits will get the bits for humidity and temperature, dispatching to
+// whichever capture backend this DHT was configured with.
 func (dht *DHT) readBits() ([]int, error) {
+	if dht.backend == BackendCGPIOMem {
+		return dht.readBitsCGPIOMem()
+	}
+	return dht.readBitsPeriph()
+}
+
+// readBitsPeriph gets the bits for humidity and temperature with the pure Go
+// busy-read loop through periph.io. This is the default backend.
+func (dht *DHT) readBitsPeriph() ([]int, error) {
 	// create variables ahead of time before critical timing part
 	var i int
 	var startTime time.Time
@@ -98,6 +166,14 @@ func (dht *DHT) readBits() ([]int, error) {
 		return nil, fmt.Errorf("pin out high error: %v", err)
 	}
 
+	return decodePulses(levels, durations)
+}
+
+// decodePulses converts a captured level/duration pulse train into the 40
+// data bits, shared by every readBits backend.
+func decodePulses(levels []gpio.Level, durations []time.Duration) ([]int, error) {
+	var i int
+
 	// get last low reading so know start of data
 	var endNumber int
 	for i = len(levels) - 1; ; i-- {
@@ -1,3 +1,4 @@
+//go:build windows
 // +build windows
 
 package dht
@@ -10,7 +11,7 @@ import (
 // NewDHT to create a new DHT struct.
 // sensorType is dht11 for DHT11, anything else for AM2302 / DHT22.
 func NewDHT(pinName string, temperatureUnit TemperatureUnit, sensorType string) (*DHT, error) {
-	dht := &DHT{temperatureUnit: temperatureUnit}
+	dht := &DHT{temperatureUnit: temperatureUnit, logger: NopLogger{}}
 
 	// set sensorType
 	sensorType = strings.ToLower(sensorType)
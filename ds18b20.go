@@ -0,0 +1,114 @@
+package dht
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DS18B20 interfaces with a DS18B20 1-Wire temperature sensor through the Linux
+// w1 kernel driver (w1-gpio / w1-therm). Call NewDS18B20 to create a new one.
+type DS18B20 struct {
+	devicePath      string
+	temperatureUnit TemperatureUnit
+	lastRead        time.Time
+	logger          Logger
+}
+
+// NewDS18B20 creates a new DS18B20 struct.
+// oneWireID is the device's 1-Wire ID as it appears under /sys/bus/w1/devices,
+// for example "28-000005e3aabc".
+func NewDS18B20(oneWireID string, temperatureUnit TemperatureUnit) (*DS18B20, error) {
+	devicePath := "/sys/bus/w1/devices/" + oneWireID + "/w1_slave"
+	if _, err := os.Stat(devicePath); err != nil {
+		return nil, fmt.Errorf("w1_slave file error: %v", err)
+	}
+
+	return &DS18B20{
+		devicePath:      devicePath,
+		temperatureUnit: temperatureUnit,
+		lastRead:        time.Now().Add(-1 * time.Second),
+		logger:          NopLogger{},
+	}, nil
+}
+
+// Read reads the sensor once, returning a Measurement or an error.
+// Note that Read will sleep for at least 1 second since the last call, as the
+// DS18B20 takes up to 750 ms to complete a conversion.
+func (d *DS18B20) Read(ctx context.Context) (Measurement, error) {
+	sleepTime := time.Second - time.Since(d.lastRead)
+	if sleepTime > 0 {
+		select {
+		case <-time.After(sleepTime):
+		case <-ctx.Done():
+			return Measurement{}, ctx.Err()
+		}
+	}
+	d.lastRead = time.Now()
+
+	data, err := os.ReadFile(d.devicePath)
+	if err != nil {
+		d.logger.Warnf("w1_slave read error: %v", err)
+		return Measurement{}, fmt.Errorf("w1_slave read error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		d.logger.Warnf("bad data - unexpected w1_slave format")
+		return Measurement{}, fmt.Errorf("bad data - unexpected w1_slave format")
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[0]), "YES") {
+		d.logger.Warnf("bad data - crc check fail")
+		return Measurement{}, fmt.Errorf("bad data - crc check fail")
+	}
+
+	index := strings.Index(lines[1], "t=")
+	if index == -1 {
+		d.logger.Warnf("bad data - temperature not found")
+		return Measurement{}, fmt.Errorf("bad data - temperature not found")
+	}
+
+	milliCelsius, err := strconv.Atoi(lines[1][index+2:])
+	if err != nil {
+		d.logger.Warnf("temperature parse error: %v", err)
+		return Measurement{}, fmt.Errorf("temperature parse error: %v", err)
+	}
+
+	temperature := float64(milliCelsius) / 1000.0
+	if d.temperatureUnit == Fahrenheit {
+		temperature = temperature*9.0/5.0 + 32.0
+	}
+
+	d.logger.Infof("read ok: temperature=%.3f", temperature)
+
+	return Measurement{Temperature: temperature, Unit: d.temperatureUnit, Time: time.Now()}, nil
+}
+
+// SetLogger sets the Logger that read errors and successful reads are
+// reported to. The default is NopLogger, which discards everything. Passing
+// nil resets it back to NopLogger.
+func (d *DS18B20) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	d.logger = logger
+}
+
+// ReadBackground it meant to be run in the background, run as a Goroutine.
+// sleepDuration is how long it will try to sleep between reads.
+// events is optional; pass nil to skip it. If non-nil, a ReadEvent is sent
+// after every read attempt, see ReadEvent.
+// Will continue to read sensor until stop is closed.
+// After it has been stopped, the stopped chan will be closed.
+// Will panic if measurement or stopped are nil.
+func (d *DS18B20) ReadBackground(measurement *Measurement, events chan<- ReadEvent, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{}) {
+	readBackgroundLoop(d.Read, measurement, events, sleepDuration, stop, stopped)
+}
+
+// Close is a no-op. DS18B20 has no resources to release.
+func (d *DS18B20) Close() error {
+	return nil
+}
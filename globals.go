@@ -1,10 +1,21 @@
 package dht
 
 import (
+	"context"
+	"errors"
 	"periph.io/x/conn/v3/gpio"
 	"time"
 )
 
+// ErrRecovered is returned by Read when MaxConsecutiveErrors was exceeded and
+// RecoveryFunc ran successfully. The Measurement is not valid; call Read again.
+var ErrRecovered = errors.New("dht: bus recovered after consecutive errors")
+
+// ErrRecoveryFailed is wrapped by the error Read returns, together with the
+// underlying error's message, when MaxConsecutiveErrors was exceeded and
+// RecoveryFunc itself failed. The underlying error itself is not wrapped.
+var ErrRecoveryFailed = errors.New("dht: bus recovery failed")
+
 // TemperatureUnit is the temperature unit wanted, either Celsius or Fahrenheit
 type TemperatureUnit int
 
@@ -15,6 +26,19 @@ const (
 	Fahrenheit
 )
 
+// Backend selects how DHT captures the sensor's pulse train.
+type Backend int
+
+const (
+	// BackendPeriph reads pulses with a pure Go busy loop through periph.io.
+	// This is the default, and the only backend available on non-Linux or non-cgo builds.
+	BackendPeriph Backend = iota
+	// BackendCGPIOMem reads pulses through a cgo capture loop that mmaps
+	// /dev/gpiomem directly and times edges with clock_gettime, for boards
+	// where Go's scheduler and time.Now overhead miss edges. Linux and cgo only.
+	BackendCGPIOMem
+)
+
 // DHT struct to interface with the sensor.
 // Call NewDHT to create a new one.
 type DHT struct {
@@ -23,4 +47,22 @@ type DHT struct {
 	sensorType      string
 	numErrors       int
 	lastRead        time.Time
+	backend         Backend
+	logger          Logger
+
+	// MaxConsecutiveErrors is how many reads in a row may fail before Read
+	// invokes RecoveryFunc. 0 disables recovery.
+	MaxConsecutiveErrors int
+	// RecoveryFunc is invoked by Read once MaxConsecutiveErrors is exceeded.
+	// It gets the ctx passed to Read, and should stop promptly once it is
+	// done. See DefaultRecoveryFunc for the built-in power-cycle implementation.
+	RecoveryFunc func(context.Context, *DHT) error
+	// RecoveryPowerDownDuration is how long DefaultRecoveryFunc holds the pin
+	// low during recovery. Defaults to 20 seconds if left zero.
+	RecoveryPowerDownDuration time.Duration
+
+	// Smoothing, if set, makes Read transparently repeat the transaction
+	// across the configured window and return a filtered value. Nil disables
+	// smoothing, which is the default.
+	Smoothing *SmoothingConfig
 }
@@ -0,0 +1,65 @@
+package dht
+
+import (
+	"fmt"
+	"log"
+)
+
+// Logger receives structured log lines from DHT: every retry, checksum
+// failure, timing anomaly, and successful read. Implement this to route
+// those diagnostics into your own logging system. Set it with SetLogger.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// NopLogger discards every log line. It is the default Logger for a new DHT.
+type NopLogger struct{}
+
+// Debugf discards the log line.
+func (NopLogger) Debugf(format string, args ...interface{}) {}
+
+// Infof discards the log line.
+func (NopLogger) Infof(format string, args ...interface{}) {}
+
+// Warnf discards the log line.
+func (NopLogger) Warnf(format string, args ...interface{}) {}
+
+// Errorf discards the log line.
+func (NopLogger) Errorf(format string, args ...interface{}) {}
+
+// StdLogger adapts the standard library log package to the Logger interface,
+// prefixing each line with its level.
+type StdLogger struct {
+	*log.Logger
+}
+
+// NewStdLogger creates a StdLogger writing through logger. Pass nil to use log.Default().
+func NewStdLogger(logger *log.Logger) *StdLogger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &StdLogger{Logger: logger}
+}
+
+// Debugf logs at debug level.
+func (s *StdLogger) Debugf(format string, args ...interface{}) {
+	s.Output(2, "DEBUG "+fmt.Sprintf(format, args...))
+}
+
+// Infof logs at info level.
+func (s *StdLogger) Infof(format string, args ...interface{}) {
+	s.Output(2, "INFO "+fmt.Sprintf(format, args...))
+}
+
+// Warnf logs at warn level.
+func (s *StdLogger) Warnf(format string, args ...interface{}) {
+	s.Output(2, "WARN "+fmt.Sprintf(format, args...))
+}
+
+// Errorf logs at error level.
+func (s *StdLogger) Errorf(format string, args ...interface{}) {
+	s.Output(2, "ERROR "+fmt.Sprintf(format, args...))
+}
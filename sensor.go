@@ -0,0 +1,119 @@
+package dht
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Measurement holds a single reading taken from a Sensor.
+type Measurement struct {
+	Temperature float64
+	Humidity    float64
+	Unit        TemperatureUnit
+	Time        time.Time
+}
+
+// DewPoint computes the dew point from Temperature and Humidity using the
+// Magnus formula: gamma = ln(RH/100) + (17.62*T)/(243.12+T), Td =
+// 243.12*gamma/(17.62-gamma), with T in Celsius. The result is converted back
+// to m.Unit.
+func (m Measurement) DewPoint() float64 {
+	temperatureC := m.Temperature
+	if m.Unit == Fahrenheit {
+		temperatureC = (m.Temperature - 32.0) * 5.0 / 9.0
+	}
+
+	gamma := math.Log(m.Humidity/100.0) + (17.62*temperatureC)/(243.12+temperatureC)
+	dewPointC := 243.12 * gamma / (17.62 - gamma)
+
+	if m.Unit == Fahrenheit {
+		return dewPointC*9.0/5.0 + 32.0
+	}
+	return dewPointC
+}
+
+// ReadEvent reports the outcome of one ReadBackground iteration, letting
+// callers drive metrics or alerting without polling the stored Measurement.
+type ReadEvent struct {
+	Time              time.Time
+	Humidity          float64
+	Temperature       float64
+	Err               error
+	ConsecutiveErrors int
+}
+
+// Sensor is implemented by every environmental sensor this package supports:
+// DHT11/AM2302/DHT22 (NewDHT), SHT1x (NewSHT1x), and DS18B20 (NewDS18B20).
+// SHT3x and BME280 support was considered but is out of scope for now; add
+// them as their own NewSHT3x/NewBME280 constructors returning a Sensor when
+// that work is picked up. Callers that need to support more than one sensor
+// type do not have to special case each one.
+type Sensor interface {
+	// Read takes a single measurement from the sensor, blocking until it is ready
+	// or ctx is done.
+	Read(ctx context.Context) (Measurement, error)
+
+	// ReadBackground is meant to be run in the background, run as a goroutine.
+	// It stores every successful reading into measurement.
+	// events is optional; pass nil to skip it. If non-nil, a ReadEvent is sent
+	// (non-blocking, dropped if nobody is listening) after every read attempt.
+	// Will continue to read the sensor until stop is closed.
+	// After it has been stopped, the stopped chan will be closed.
+	ReadBackground(measurement *Measurement, events chan<- ReadEvent, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{})
+
+	// Close releases any resources held by the sensor.
+	Close() error
+}
+
+// readBackgroundLoop is the common ReadBackground loop shared by every Sensor
+// implementation: call read, store successful measurements, report a
+// ReadEvent, and stop when told to.
+func readBackgroundLoop(read func(context.Context) (Measurement, error), measurement *Measurement, events chan<- ReadEvent, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{}) {
+	var startTime time.Time
+	var consecutiveErrors int
+
+Loop:
+	for {
+		startTime = time.Now()
+		m, err := read(context.Background())
+		if err == nil {
+			// no read error, save result
+			consecutiveErrors = 0
+			*measurement = m
+		} else {
+			consecutiveErrors++
+		}
+
+		if events != nil {
+			event := ReadEvent{Time: time.Now(), Err: err, ConsecutiveErrors: consecutiveErrors}
+			if err == nil {
+				event.Humidity = m.Humidity
+				event.Temperature = m.Temperature
+			}
+			select {
+			case events <- event:
+			default:
+				// do not block the read loop if nobody is listening
+			}
+		}
+
+		if err == nil {
+			// wait for sleepDuration or stop
+			select {
+			case <-time.After(sleepDuration - time.Since(startTime)):
+			case <-stop:
+				break Loop
+			}
+		} else {
+			// read error, just check for stop
+			select {
+			case <-stop:
+				break Loop
+			default:
+			}
+		}
+	}
+
+	close(stopped)
+}
@@ -0,0 +1,141 @@
+package dht
+
+import (
+	"context"
+	"fmt"
+	"periph.io/x/conn/v3/gpio"
+	"time"
+)
+
+// sht1xPins holds the two GPIO pins an SHT1x is wired to. It is kept separate
+// from SHT1x the same way DHT keeps its pin separate from readBits, since how
+// the pins are driven is platform specific.
+type sht1xPins struct {
+	data  gpio.PinIO
+	clock gpio.PinIO
+}
+
+// SHT1x interfaces with a Sensirion SHT1x / SHT7x sensor over its two-wire
+// clocked protocol (DATA + SCK, not I2C). Call NewSHT1x to create a new one.
+type SHT1x struct {
+	temperatureUnit TemperatureUnit
+	lastRead        time.Time
+	pins            *sht1xPins
+	logger          Logger
+}
+
+// sht1x commands, 3 address bits + 5 command bits, see the Sensirion datasheet.
+const (
+	sht1xCmdMeasureTemperature = 0x03
+	sht1xCmdMeasureHumidity    = 0x05
+	sht1xCmdSoftReset          = 0x1E
+)
+
+// sht1x conversion coefficients for 14 bit temperature and 12 bit humidity
+// readings at VDD = 5V, see the Sensirion datasheet.
+const (
+	sht1xTemperatureD1 = -40.0
+	sht1xTemperatureD2 = 0.01
+
+	sht1xHumidityC1 = -2.0468
+	sht1xHumidityC2 = 0.0367
+	sht1xHumidityC3 = -1.5955e-6
+
+	sht1xHumidityT1 = 0.01
+	sht1xHumidityT2 = 0.00008
+)
+
+// Read reads the sensor once, returning a Measurement with Temperature and
+// Humidity set, from which DewPoint can be computed, or an error.
+// Note that Read will sleep for at least 250 milliseconds between calls, as back
+// to back measurements need time for the sensor to recover between transactions.
+func (s *SHT1x) Read(ctx context.Context) (Measurement, error) {
+	sleepTime := 250*time.Millisecond - time.Since(s.lastRead)
+	if sleepTime > 0 {
+		select {
+		case <-time.After(sleepTime):
+		case <-ctx.Done():
+			return Measurement{}, ctx.Err()
+		}
+	}
+
+	rawTemperature, err := s.measure(sht1xCmdMeasureTemperature)
+	if err != nil {
+		s.logger.Warnf("measure temperature error: %v", err)
+		return Measurement{}, fmt.Errorf("measure temperature error: %v", err)
+	}
+
+	rawHumidity, err := s.measure(sht1xCmdMeasureHumidity)
+	if err != nil {
+		s.logger.Warnf("measure humidity error: %v", err)
+		return Measurement{}, fmt.Errorf("measure humidity error: %v", err)
+	}
+	s.lastRead = time.Now()
+
+	temperatureC := sht1xTemperatureD1 + sht1xTemperatureD2*float64(rawTemperature)
+
+	rhLinear := sht1xHumidityC1 + sht1xHumidityC2*float64(rawHumidity) + sht1xHumidityC3*float64(rawHumidity)*float64(rawHumidity)
+	humidity := (temperatureC-25.0)*(sht1xHumidityT1+sht1xHumidityT2*float64(rawHumidity)) + rhLinear
+	if humidity < 0 {
+		humidity = 0
+	} else if humidity > 100 {
+		humidity = 100
+	}
+
+	temperature := temperatureC
+	if s.temperatureUnit == Fahrenheit {
+		temperature = temperatureC*9.0/5.0 + 32.0
+	}
+
+	s.logger.Infof("read ok: humidity=%.1f temperature=%.1f", humidity, temperature)
+
+	return Measurement{Humidity: humidity, Temperature: temperature, Unit: s.temperatureUnit, Time: time.Now()}, nil
+}
+
+// SetLogger sets the Logger that measurement errors and successful reads are
+// reported to. The default is NopLogger, which discards everything. Passing
+// nil resets it back to NopLogger.
+func (s *SHT1x) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	s.logger = logger
+}
+
+// ReadBackground it meant to be run in the background, run as a Goroutine.
+// sleepDuration is how long it will try to sleep between reads.
+// events is optional; pass nil to skip it. If non-nil, a ReadEvent is sent
+// after every read attempt, see ReadEvent.
+// Will continue to read sensor until stop is closed.
+// After it has been stopped, the stopped chan will be closed.
+// Will panic if measurement or stopped are nil.
+func (s *SHT1x) ReadBackground(measurement *Measurement, events chan<- ReadEvent, sleepDuration time.Duration, stop chan struct{}, stopped chan struct{}) {
+	readBackgroundLoop(s.Read, measurement, events, sleepDuration, stop, stopped)
+}
+
+// sht1xCRC8Update folds one more byte into a running Sensirion CRC8 checksum.
+// The polynomial is x^8 + x^5 + x^4 + 1 (0x31), reflected to 0x8C since the
+// sensor transmits its checksum bit reversed, see application note AN#1.
+func sht1xCRC8Update(crc uint8, b byte) uint8 {
+	crc ^= b
+	for i := 0; i < 8; i++ {
+		if crc&0x01 != 0 {
+			crc = (crc >> 1) ^ 0x8C
+		} else {
+			crc >>= 1
+		}
+	}
+	return crc
+}
+
+// sht1xReverseBits reverses the bit order of a byte, needed to check the
+// sensor's CRC since it is transmitted LSB first unlike the data bytes.
+func sht1xReverseBits(b byte) byte {
+	var out byte
+	for i := 0; i < 8; i++ {
+		out <<= 1
+		out |= b & 0x01
+		b >>= 1
+	}
+	return out
+}
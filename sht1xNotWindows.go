@@ -0,0 +1,221 @@
+//go:build !windows
+// +build !windows
+
+package dht
+
+import (
+	"fmt"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"time"
+)
+
+// NewSHT1x creates a new SHT1x struct.
+// dataPin and clockPin are the two pins the sensor's DATA and SCK lines are wired to.
+func NewSHT1x(dataPin string, clockPin string, temperatureUnit TemperatureUnit) (*SHT1x, error) {
+	data := gpioreg.ByName(dataPin)
+	if data == nil {
+		return nil, fmt.Errorf("data pin is nil")
+	}
+	clock := gpioreg.ByName(clockPin)
+	if clock == nil {
+		return nil, fmt.Errorf("clock pin is nil")
+	}
+
+	pins := &sht1xPins{data: data, clock: clock}
+	if err := pins.reset(); err != nil {
+		return nil, fmt.Errorf("reset error: %v", err)
+	}
+
+	return &SHT1x{
+		temperatureUnit: temperatureUnit,
+		lastRead:        time.Now().Add(-250 * time.Millisecond),
+		pins:            pins,
+		logger:          NopLogger{},
+	}, nil
+}
+
+// start sends the SHT1x transmission start sequence:
+// DATA high to low while SCK is high, then SCK low to high to low, DATA low to high.
+func (p *sht1xPins) start() error {
+	if err := p.data.Out(gpio.High); err != nil {
+		return err
+	}
+	if err := p.clock.Out(gpio.Low); err != nil {
+		return err
+	}
+	if err := p.clock.Out(gpio.High); err != nil {
+		return err
+	}
+	if err := p.data.Out(gpio.Low); err != nil {
+		return err
+	}
+	if err := p.clock.Out(gpio.Low); err != nil {
+		return err
+	}
+	if err := p.clock.Out(gpio.High); err != nil {
+		return err
+	}
+	if err := p.data.Out(gpio.High); err != nil {
+		return err
+	}
+	return p.clock.Out(gpio.Low)
+}
+
+// reset clocks 9 high idle bits followed by a soft reset command, used to
+// bring the sensor back to a known state after a communication error.
+func (p *sht1xPins) reset() error {
+	if err := p.data.Out(gpio.High); err != nil {
+		return err
+	}
+	for i := 0; i < 9; i++ {
+		if err := p.clock.Out(gpio.High); err != nil {
+			return err
+		}
+		if err := p.clock.Out(gpio.Low); err != nil {
+			return err
+		}
+	}
+	if err := p.start(); err != nil {
+		return err
+	}
+	return p.writeByte(sht1xCmdSoftReset)
+}
+
+// writeByte clocks out one byte, MSB first, returning an error if the sensor did not ack it.
+func (p *sht1xPins) writeByte(b byte) error {
+	if err := p.data.Out(gpio.High); err != nil {
+		return err
+	}
+	for i := 7; i >= 0; i-- {
+		level := gpio.Low
+		if b&(1<<uint(i)) != 0 {
+			level = gpio.High
+		}
+		if err := p.data.Out(level); err != nil {
+			return err
+		}
+		if err := p.clock.Out(gpio.High); err != nil {
+			return err
+		}
+		if err := p.clock.Out(gpio.Low); err != nil {
+			return err
+		}
+	}
+
+	// release data and clock the ACK bit, sensor should pull data low
+	if err := p.data.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		return err
+	}
+	if err := p.clock.Out(gpio.High); err != nil {
+		return err
+	}
+	ack := p.data.Read()
+	if err := p.clock.Out(gpio.Low); err != nil {
+		return err
+	}
+	if ack != gpio.Low {
+		return fmt.Errorf("no ack from sensor")
+	}
+	return nil
+}
+
+// readByte clocks in one byte, MSB first, sending ack so the sensor knows
+// whether to send another byte.
+func (p *sht1xPins) readByte(ack bool) (byte, error) {
+	if err := p.data.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		return 0, err
+	}
+
+	var b byte
+	for i := 0; i < 8; i++ {
+		if err := p.clock.Out(gpio.High); err != nil {
+			return 0, err
+		}
+		b <<= 1
+		if p.data.Read() == gpio.High {
+			b |= 1
+		}
+		if err := p.clock.Out(gpio.Low); err != nil {
+			return 0, err
+		}
+	}
+
+	ackLevel := gpio.High
+	if ack {
+		ackLevel = gpio.Low
+	}
+	if err := p.data.Out(ackLevel); err != nil {
+		return 0, err
+	}
+	if err := p.clock.Out(gpio.High); err != nil {
+		return 0, err
+	}
+	if err := p.clock.Out(gpio.Low); err != nil {
+		return 0, err
+	}
+
+	return b, nil
+}
+
+// waitForReady waits for the sensor to pull DATA low, signaling the
+// measurement is ready, or returns an error after timeout.
+func (p *sht1xPins) waitForReady(timeout time.Duration) error {
+	if err := p.data.In(gpio.PullUp, gpio.NoEdge); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	for p.data.Read() == gpio.High {
+		if time.Since(startTime) > timeout {
+			return fmt.Errorf("timed out waiting for measurement")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+// measure runs one measurement command, returning the raw 16 bit sensor value.
+func (s *SHT1x) measure(command byte) (uint16, error) {
+	if err := s.pins.start(); err != nil {
+		return 0, fmt.Errorf("start error: %v", err)
+	}
+	if err := s.pins.writeByte(command); err != nil {
+		return 0, fmt.Errorf("write command error: %v", err)
+	}
+
+	// humidity conversion is faster than temperature, but 320ms covers both
+	if err := s.pins.waitForReady(320 * time.Millisecond); err != nil {
+		return 0, err
+	}
+
+	msb, err := s.pins.readByte(true)
+	if err != nil {
+		return 0, fmt.Errorf("read msb error: %v", err)
+	}
+	lsb, err := s.pins.readByte(true)
+	if err != nil {
+		return 0, fmt.Errorf("read lsb error: %v", err)
+	}
+	crc, err := s.pins.readByte(false)
+	if err != nil {
+		return 0, fmt.Errorf("read crc error: %v", err)
+	}
+
+	check := sht1xCRC8Update(0, sht1xReverseBits(command))
+	check = sht1xCRC8Update(check, sht1xReverseBits(msb))
+	check = sht1xCRC8Update(check, sht1xReverseBits(lsb))
+	if check != crc {
+		return 0, fmt.Errorf("bad data - crc check fail")
+	}
+
+	return uint16(msb)<<8 | uint16(lsb), nil
+}
+
+// Close sets both pins back to a high idle state. SHT1x has no other resources to release.
+func (s *SHT1x) Close() error {
+	if err := s.pins.data.Out(gpio.High); err != nil {
+		return err
+	}
+	return s.pins.clock.Out(gpio.High)
+}
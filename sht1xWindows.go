@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package dht
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewSHT1x creates a new SHT1x struct.
+// dataPin and clockPin are the two pins the sensor's DATA and SCK lines are wired to.
+func NewSHT1x(dataPin string, clockPin string, temperatureUnit TemperatureUnit) (*SHT1x, error) {
+	return &SHT1x{
+		temperatureUnit: temperatureUnit,
+		lastRead:        time.Now().Add(-250 * time.Millisecond),
+		logger:          NopLogger{},
+	}, nil
+}
+
+// measure is not supported on windows, there is no GPIO access.
+func (s *SHT1x) measure(command byte) (uint16, error) {
+	return 0, fmt.Errorf("measure not supported on windows")
+}
+
+// Close is a no-op on windows, there are no pins to release.
+func (s *SHT1x) Close() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package dht
+
+import "testing"
+
+func TestSHT1xReverseBits(t *testing.T) {
+	tests := []struct {
+		in   byte
+		want byte
+	}{
+		{0x00, 0x00},
+		{0xFF, 0xFF},
+		{0x01, 0x80},
+		{0x80, 0x01},
+		{0b00000011, 0b11000000},
+		{0b10110000, 0b00001101},
+	}
+
+	for _, tt := range tests {
+		if got := sht1xReverseBits(tt.in); got != tt.want {
+			t.Errorf("sht1xReverseBits(%08b) = %08b, want %08b", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSHT1xCRC8Update(t *testing.T) {
+	crc := sht1xCRC8Update(0, sht1xReverseBits(sht1xCmdMeasureTemperature))
+	if crc == 0 {
+		t.Fatalf("expected a non-zero crc for a non-zero command byte")
+	}
+
+	other := sht1xCRC8Update(0, sht1xReverseBits(sht1xCmdMeasureHumidity))
+	if crc == other {
+		t.Fatalf("expected different crc values for different command bytes")
+	}
+
+	// folding in more bytes should keep changing the running crc
+	withData := sht1xCRC8Update(crc, 0x42)
+	if withData == crc {
+		t.Fatalf("expected crc to change after folding in another byte")
+	}
+}
@@ -0,0 +1,120 @@
+package dht
+
+import (
+	"math"
+	"sort"
+)
+
+// SmoothingMode selects how DHT.Read filters the window of readings taken
+// when SmoothingConfig is set.
+type SmoothingMode int
+
+const (
+	// SmoothingMean averages the window, dropping samples further than
+	// MaxStdDev standard deviations from the mean. MaxStdDev <= 0 disables
+	// the rejection step and just averages the whole window.
+	SmoothingMean SmoothingMode = iota
+	// SmoothingMedian takes the median of the window. MaxStdDev is unused.
+	SmoothingMedian
+)
+
+// SmoothingConfig, set as DHT.Smoothing, makes Read transparently repeat the
+// transaction WindowSize times and return a filtered value, rejecting the
+// physically impossible spikes DHT22 units frequently emit.
+type SmoothingConfig struct {
+	// WindowSize is how many transactions Read repeats. Values <= 1 disable smoothing.
+	WindowSize int
+	// MaxStdDev is the outlier rejection threshold used by SmoothingMean.
+	MaxStdDev float64
+	// Mode selects the filtering algorithm, SmoothingMean by default.
+	Mode SmoothingMode
+}
+
+// smoothSamples filters humidity and temperature across samples independently,
+// according to cfg.Mode, keeping the Unit and Time of the last sample.
+func smoothSamples(samples []Measurement, cfg *SmoothingConfig) Measurement {
+	humidities := make([]float64, len(samples))
+	temperatures := make([]float64, len(samples))
+	for i, m := range samples {
+		humidities[i] = m.Humidity
+		temperatures[i] = m.Temperature
+	}
+
+	var humidity, temperature float64
+	if cfg.Mode == SmoothingMedian {
+		humidity = median(humidities)
+		temperature = median(temperatures)
+	} else {
+		humidity = filteredMean(humidities, cfg.MaxStdDev)
+		temperature = filteredMean(temperatures, cfg.MaxStdDev)
+	}
+
+	last := samples[len(samples)-1]
+	return Measurement{Humidity: humidity, Temperature: temperature, Unit: last.Unit, Time: last.Time}
+}
+
+// median returns the median of values, without modifying values.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// filteredMean returns the mean of values, excluding any more than maxStdDev
+// standard deviations from the mean. maxStdDev <= 0 disables rejection.
+func filteredMean(values []float64, maxStdDev float64) float64 {
+	mean, stdDev := meanStdDev(values)
+	if maxStdDev <= 0 || stdDev == 0 {
+		return mean
+	}
+
+	var sum float64
+	var count int
+	for _, v := range values {
+		if math.Abs(v-mean) <= maxStdDev*stdDev {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		// every sample was rejected, fall back to the unfiltered mean
+		return mean
+	}
+	return sum / float64(count)
+}
+
+// meanStdDev returns the mean and population standard deviation of values.
+func meanStdDev(values []float64) (mean float64, stdDev float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean = sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
+
+// dropMinMaxMean sorts a copy of values, drops the minimum and maximum, and
+// averages what remains. values must have at least 3 elements.
+func dropMinMaxMean(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	sorted = sorted[1 : len(sorted)-1]
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return sum / float64(len(sorted))
+}
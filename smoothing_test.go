@@ -0,0 +1,67 @@
+package dht
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{1, 2, 3}, 2},
+		{[]float64{1, 2, 3, 4}, 2.5},
+		{[]float64{5}, 5},
+		{[]float64{3, 1, 2}, 2},
+	}
+
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestFilteredMean(t *testing.T) {
+	values := []float64{10, 10, 10, 10, 1000}
+
+	if got := filteredMean(values, 1); got != 10 {
+		t.Errorf("filteredMean with outlier rejected = %v, want 10", got)
+	}
+
+	want := (10 + 10 + 10 + 10 + 1000) / 5.0
+	if got := filteredMean(values, 0); got != want {
+		t.Errorf("filteredMean with rejection disabled = %v, want %v", got, want)
+	}
+}
+
+func TestDropMinMaxMean(t *testing.T) {
+	got := dropMinMaxMean([]float64{1, 100, 2, 3, 4})
+	want := (2.0 + 3.0 + 4.0) / 3.0
+	if got != want {
+		t.Errorf("dropMinMaxMean() = %v, want %v", got, want)
+	}
+}
+
+func TestMeasurementDewPoint(t *testing.T) {
+	m := Measurement{Temperature: 25, Humidity: 50, Unit: Celsius}
+	if got := m.DewPoint(); math.Abs(got-13.86) > 0.1 {
+		t.Errorf("DewPoint() = %v, want ~13.86", got)
+	}
+
+	// 77F == 25C, dew point should agree once converted back to Fahrenheit
+	mf := Measurement{Temperature: 77, Humidity: 50, Unit: Fahrenheit}
+	wantF := 13.86*9.0/5.0 + 32.0
+	if got := mf.DewPoint(); math.Abs(got-wantF) > 0.3 {
+		t.Errorf("DewPoint() Fahrenheit = %v, want ~%v", got, wantF)
+	}
+}
+
+func TestMeasurementDewPointLowHumidity(t *testing.T) {
+	m := Measurement{Temperature: 20, Humidity: 1, Unit: Celsius}
+	got := m.DewPoint()
+	if math.IsNaN(got) || math.IsInf(got, 0) {
+		t.Errorf("DewPoint() with low humidity = %v, want a finite value", got)
+	}
+}